@@ -0,0 +1,74 @@
+/*
+Copyright 2014 Gavin Bong.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+either express or implied. See the License for the specific
+language governing permissions and limitations under the
+License.
+*/
+
+// Package comparator provides redblacktree.Comparator implementations
+// for common key types, for use with redblacktree.NewTreeWith.
+package comparator
+
+import "time"
+
+// Int compares two int keys.
+func Int(a, b interface{}) int {
+	x, y := a.(int), b.(int)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Int64 compares two int64 keys.
+func Int64(a, b interface{}) int {
+	x, y := a.(int64), b.(int64)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// String compares two string keys lexicographically.
+func String(a, b interface{}) int {
+	x, y := a.(string), b.(string)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Time compares two time.Time keys chronologically.
+func Time(a, b interface{}) int {
+	x, y := a.(time.Time), b.(time.Time)
+	switch {
+	case x.Before(y):
+		return -1
+	case x.After(y):
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,499 @@
+/*
+Copyright 2014 Gavin Bong.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+either express or implied. See the License for the specific
+language governing permissions and limitations under the
+License.
+*/
+
+// Package redblacktree implements a CLRS-style red-black tree keyed
+// by any type a Comparator knows how to order.
+package redblacktree
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/andir/redblacktree/comparator"
+)
+
+// Comparator reports the sign of (a - b): negative if a orders
+// before b, positive if a orders after b, zero if they are equal.
+// Implementations must be consistent with the Comparator a Tree was
+// built with, since two keys may only ever be compared to each
+// other via that one function.
+type Comparator func(a, b interface{}) int
+
+// Direction describes which child of a parent node a key occupies
+// (or would occupy, for a key not yet present in the tree).
+type Direction int
+
+const (
+	// NODIR is returned whenever a direction is not applicable,
+	// e.g. for the root node which has no parent.
+	NODIR Direction = iota
+	// LEFT means the node is (or would be) the left child of its parent.
+	LEFT
+	// RIGHT means the node is (or would be) the right child of its parent.
+	RIGHT
+)
+
+func (d Direction) String() string {
+	switch d {
+	case LEFT:
+		return "LEFT"
+	case RIGHT:
+		return "RIGHT"
+	default:
+		return "NODIR"
+	}
+}
+
+const (
+	red   = true
+	black = false
+)
+
+// Node is a single node of a Tree. Its fields are unexported since
+// callers interact with a Tree through Put/Get/Delete/Walk rather
+// than the node structure directly.
+type Node struct {
+	value  interface{}
+	data   interface{}
+	color  bool
+	left   *Node
+	right  *Node
+	parent *Node
+}
+
+// Tree is a mutable red-black tree keyed by whatever type cmp orders.
+type Tree struct {
+	root *Node
+	size uint64
+	cmp  Comparator
+}
+
+// NewTree returns an empty Tree keyed by int, for backwards
+// compatibility with code written before NewTreeWith existed.
+func NewTree() *Tree {
+	return NewTreeWith(comparator.Int)
+}
+
+// NewTreeWith returns an empty Tree ordered by cmp.
+func NewTreeWith(cmp Comparator) *Tree {
+	return &Tree{cmp: cmp}
+}
+
+var traceEnabled bool
+
+// TraceOn enables diagnostic tracing of rotations to stdout.
+func TraceOn() {
+	traceEnabled = true
+}
+
+// TraceOff disables diagnostic tracing. This is the default.
+func TraceOff() {
+	traceEnabled = false
+}
+
+func trace(format string, v ...interface{}) {
+	if traceEnabled {
+		fmt.Printf(format+"\n", v...)
+	}
+}
+
+func isRed(n *Node) bool {
+	if n == nil {
+		return false
+	}
+	return n.color == red
+}
+
+// Size returns the number of keys currently stored in the tree.
+func (t *Tree) Size() uint64 {
+	return t.size
+}
+
+// Get looks up key and reports whether it is present, along with its
+// associated value.
+func (t *Tree) Get(key interface{}) (bool, interface{}) {
+	n := t.search(key)
+	if n == nil {
+		return false, nil
+	}
+	return true, n.data
+}
+
+func (t *Tree) search(key interface{}) *Node {
+	current := t.root
+	for current != nil {
+		switch c := t.cmp(key, current.value); {
+		case c == 0:
+			return current
+		case c < 0:
+			current = current.left
+		default:
+			current = current.right
+		}
+	}
+	return nil
+}
+
+// GetParent reports whether key is present, the parent of the node
+// holding key (nil for the root), and the direction key hangs off
+// that parent. If key is absent, parent/dir describe where it would
+// be inserted.
+func (t *Tree) GetParent(key interface{}) (bool, *Node, Direction) {
+	var parent *Node
+	dir := NODIR
+	current := t.root
+	for current != nil {
+		switch c := t.cmp(key, current.value); {
+		case c == 0:
+			return true, parent, dir
+		case c < 0:
+			parent = current
+			dir = LEFT
+			current = current.left
+		default:
+			parent = current
+			dir = RIGHT
+			current = current.right
+		}
+	}
+	return false, parent, dir
+}
+
+// RotateLeft performs a standard left tree-rotation pivoted on x. It
+// is a no-op if x or x.right is nil.
+func (t *Tree) RotateLeft(x *Node) {
+	if x == nil || x.right == nil {
+		return
+	}
+	trace("RotateLeft(%v)", x.value)
+
+	y := x.right
+	x.right = y.left
+	if y.left != nil {
+		y.left.parent = x
+	}
+	y.parent = x.parent
+
+	switch {
+	case x.parent == nil:
+		t.root = y
+	case x == x.parent.left:
+		x.parent.left = y
+	default:
+		x.parent.right = y
+	}
+
+	y.left = x
+	x.parent = y
+}
+
+// RotateRight performs a standard right tree-rotation pivoted on x. It
+// is a no-op if x or x.left is nil.
+func (t *Tree) RotateRight(x *Node) {
+	if x == nil || x.left == nil {
+		return
+	}
+	trace("RotateRight(%v)", x.value)
+
+	y := x.left
+	x.left = y.right
+	if y.right != nil {
+		y.right.parent = x
+	}
+	y.parent = x.parent
+
+	switch {
+	case x.parent == nil:
+		t.root = y
+	case x == x.parent.right:
+		x.parent.right = y
+	default:
+		x.parent.left = y
+	}
+
+	y.right = x
+	x.parent = y
+}
+
+// Put inserts key/data, or overwrites data if key is already present.
+// Overwriting an existing key does not change Size().
+func (t *Tree) Put(key interface{}, data interface{}) {
+	var parent *Node
+	current := t.root
+	for current != nil {
+		parent = current
+		switch c := t.cmp(key, current.value); {
+		case c == 0:
+			current.data = data
+			return
+		case c < 0:
+			current = current.left
+		default:
+			current = current.right
+		}
+	}
+
+	z := &Node{value: key, data: data, color: red, parent: parent}
+	switch {
+	case parent == nil:
+		t.root = z
+	case t.cmp(key, parent.value) < 0:
+		parent.left = z
+	default:
+		parent.right = z
+	}
+	t.size++
+	t.insertFixup(z)
+}
+
+func (t *Tree) insertFixup(z *Node) {
+	for z.parent != nil && isRed(z.parent) {
+		grandparent := z.parent.parent
+		if grandparent == nil {
+			break
+		}
+		if z.parent == grandparent.left {
+			uncle := grandparent.right
+			if isRed(uncle) {
+				z.parent.color = black
+				uncle.color = black
+				grandparent.color = red
+				z = grandparent
+				continue
+			}
+			if z == z.parent.right {
+				z = z.parent
+				t.RotateLeft(z)
+			}
+			z.parent.color = black
+			grandparent.color = red
+			t.RotateRight(grandparent)
+		} else {
+			uncle := grandparent.left
+			if isRed(uncle) {
+				z.parent.color = black
+				uncle.color = black
+				grandparent.color = red
+				z = grandparent
+				continue
+			}
+			if z == z.parent.left {
+				z = z.parent
+				t.RotateRight(z)
+			}
+			z.parent.color = black
+			grandparent.color = red
+			t.RotateLeft(grandparent)
+		}
+	}
+	t.root.color = black
+}
+
+// Delete removes key from the tree, reporting whether it was present
+// and, if so, the value it held.
+func (t *Tree) Delete(key interface{}) (bool, interface{}) {
+	z := t.search(key)
+	if z == nil {
+		return false, nil
+	}
+	data := z.data
+	t.deleteNode(z)
+	t.size--
+	return true, data
+}
+
+// transplant replaces the subtree rooted at u with the subtree rooted
+// at v, reparenting v to u's parent. v may be nil.
+func (t *Tree) transplant(u, v *Node) {
+	switch {
+	case u.parent == nil:
+		t.root = v
+	case u == u.parent.left:
+		u.parent.left = v
+	default:
+		u.parent.right = v
+	}
+	if v != nil {
+		v.parent = u.parent
+	}
+}
+
+func minimum(n *Node) *Node {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func (t *Tree) deleteNode(z *Node) {
+	y := z
+	yOriginalColor := y.color
+	var x *Node
+	var xParent *Node
+
+	switch {
+	case z.left == nil:
+		x = z.right
+		xParent = z.parent
+		t.transplant(z, z.right)
+	case z.right == nil:
+		x = z.left
+		xParent = z.parent
+		t.transplant(z, z.left)
+	default:
+		y = minimum(z.right)
+		yOriginalColor = y.color
+		x = y.right
+		if y.parent == z {
+			xParent = y
+		} else {
+			xParent = y.parent
+			t.transplant(y, y.right)
+			y.right = z.right
+			y.right.parent = y
+		}
+		t.transplant(z, y)
+		y.left = z.left
+		y.left.parent = y
+		y.color = z.color
+	}
+
+	if yOriginalColor == black {
+		t.deleteFixup(x, xParent)
+	}
+}
+
+// deleteFixup restores the red-black invariants after a black node
+// has been spliced out of the tree. x is the node that moved into the
+// deleted node's place (possibly nil); parent is its parent, needed
+// because a nil x has no parent pointer of its own.
+func (t *Tree) deleteFixup(x *Node, parent *Node) {
+	for x != t.root && !isRed(x) && parent != nil {
+		if x == parent.left {
+			sibling := parent.right
+			if sibling == nil {
+				break
+			}
+			if isRed(sibling) {
+				sibling.color = black
+				parent.color = red
+				t.RotateLeft(parent)
+				sibling = parent.right
+				if sibling == nil {
+					break
+				}
+			}
+			if !isRed(sibling.left) && !isRed(sibling.right) {
+				sibling.color = red
+				x = parent
+				parent = x.parent
+				continue
+			}
+			if !isRed(sibling.right) {
+				if sibling.left != nil {
+					sibling.left.color = black
+				}
+				sibling.color = red
+				t.RotateRight(sibling)
+				sibling = parent.right
+			}
+			sibling.color = parent.color
+			parent.color = black
+			if sibling.right != nil {
+				sibling.right.color = black
+			}
+			t.RotateLeft(parent)
+			x = t.root
+			parent = nil
+		} else {
+			sibling := parent.left
+			if sibling == nil {
+				break
+			}
+			if isRed(sibling) {
+				sibling.color = black
+				parent.color = red
+				t.RotateRight(parent)
+				sibling = parent.left
+				if sibling == nil {
+					break
+				}
+			}
+			if !isRed(sibling.right) && !isRed(sibling.left) {
+				sibling.color = red
+				x = parent
+				parent = x.parent
+				continue
+			}
+			if !isRed(sibling.left) {
+				if sibling.right != nil {
+					sibling.right.color = black
+				}
+				sibling.color = red
+				t.RotateLeft(sibling)
+				sibling = parent.left
+			}
+			sibling.color = parent.color
+			parent.color = black
+			if sibling.left != nil {
+				sibling.left.color = black
+			}
+			t.RotateRight(parent)
+			x = t.root
+			parent = nil
+		}
+	}
+	if x != nil {
+		x.color = black
+	}
+}
+
+// Visitor is notified of nodes as a Tree is walked.
+type Visitor interface {
+	Visit(n *Node)
+}
+
+// Walk hands the tree's root to v; any further traversal, such as
+// InorderVisitor's in-order recursion, is done by v itself.
+func (t *Tree) Walk(v Visitor) {
+	v.Visit(t.root)
+}
+
+// InorderVisitor renders a Tree as a parenthesized, in-order string:
+// a nil child is printed as ".", a node as "(left value right)".
+type InorderVisitor struct {
+	buf bytes.Buffer
+}
+
+// Visit implements Visitor. It recurses on n's children so that
+// calling it on the tree's root builds the whole representation.
+func (iv *InorderVisitor) Visit(n *Node) {
+	if n == nil {
+		iv.buf.WriteString(".")
+		return
+	}
+	iv.buf.WriteString("(")
+	iv.Visit(n.left)
+	fmt.Fprintf(&iv.buf, "%v", n.value)
+	iv.Visit(n.right)
+	iv.buf.WriteString(")")
+}
+
+// String returns the representation accumulated by Visit calls.
+func (iv *InorderVisitor) String() string {
+	return iv.buf.String()
+}
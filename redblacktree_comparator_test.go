@@ -0,0 +1,93 @@
+/*
+Copyright 2014 Gavin Bong.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+either express or implied. See the License for the specific
+language governing permissions and limitations under the
+License.
+*/
+
+package redblacktree
+
+import (
+	"testing"
+
+	"github.com/andir/redblacktree/comparator"
+)
+
+func TestNewTreeWithStringComparator(t *testing.T) {
+	t1 := NewTreeWith(comparator.String)
+	for _, s := range []string{"banana", "apple", "cherry", "date"} {
+		t1.Put(s, len(s))
+	}
+
+	it := t1.Iterator()
+	var keys []string
+	for ok := it.SeekFirst(); ok; ok = it.Next() {
+		keys = append(keys, it.Key().(string))
+	}
+
+	expected := []string{"apple", "banana", "cherry", "date"}
+	if len(keys) != len(expected) {
+		t.Fatalf("got %v, want %v", keys, expected)
+	}
+	for i := range expected {
+		if keys[i] != expected[i] {
+			t.Fatalf("got %v, want %v", keys, expected)
+		}
+	}
+}
+
+// point is a struct key used to exercise NewTreeWith with a custom,
+// user-supplied Comparator that orders by a field of the key rather
+// than the key's natural ordering.
+type point struct {
+	x, y int
+}
+
+func byX(a, b interface{}) int {
+	pa, pb := a.(point), b.(point)
+	switch {
+	case pa.x < pb.x:
+		return -1
+	case pa.x > pb.x:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func TestNewTreeWithCustomStructComparator(t *testing.T) {
+	t1 := NewTreeWith(byX)
+	t1.Put(point{x: 3, y: 1}, "c")
+	t1.Put(point{x: 1, y: 9}, "a")
+	t1.Put(point{x: 2, y: 5}, "b")
+
+	it := t1.Iterator()
+	var xs []int
+	for ok := it.SeekFirst(); ok; ok = it.Next() {
+		xs = append(xs, it.Key().(point).x)
+	}
+
+	expected := []int{1, 2, 3}
+	if len(xs) != len(expected) {
+		t.Fatalf("got %v, want %v", xs, expected)
+	}
+	for i := range expected {
+		if xs[i] != expected[i] {
+			t.Fatalf("got %v, want %v", xs, expected)
+		}
+	}
+
+	ok, v := t1.Get(point{x: 2, y: 999})
+	True(ok, t)
+	assertPayloadString("b", v.(string), t)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2014 Gavin Bong.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+either express or implied. See the License for the specific
+language governing permissions and limitations under the
+License.
+*/
+
+package redblacktree
+
+// Each calls fn with every key/value pair in the tree, in ascending
+// key order.
+func (t *Tree) Each(fn func(key interface{}, value interface{})) {
+	it := t.Iterator()
+	for ok := it.SeekFirst(); ok; ok = it.Next() {
+		fn(it.Key(), it.Value())
+	}
+}
+
+// Map builds a new Tree by applying fn to every key/value pair of t,
+// in ascending key order, and Put-ing the result. Since Put is used
+// to build the result, the red-black invariants are re-established
+// rather than the original shape being preserved.
+func (t *Tree) Map(fn func(key interface{}, value interface{}) (interface{}, interface{})) *Tree {
+	result := NewTreeWith(t.cmp)
+	t.Each(func(key interface{}, value interface{}) {
+		k, v := fn(key, value)
+		result.Put(k, v)
+	})
+	return result
+}
+
+// Select builds a new Tree containing only the key/value pairs of t
+// for which fn returns true.
+func (t *Tree) Select(fn func(key interface{}, value interface{}) bool) *Tree {
+	result := NewTreeWith(t.cmp)
+	t.Each(func(key interface{}, value interface{}) {
+		if fn(key, value) {
+			result.Put(key, value)
+		}
+	})
+	return result
+}
+
+// Any reports whether fn returns true for at least one key/value
+// pair in t.
+func (t *Tree) Any(fn func(key interface{}, value interface{}) bool) bool {
+	found := false
+	it := t.Iterator()
+	for ok := it.SeekFirst(); ok && !found; ok = it.Next() {
+		if fn(it.Key(), it.Value()) {
+			found = true
+		}
+	}
+	return found
+}
+
+// All reports whether fn returns true for every key/value pair in t.
+// It is vacuously true for an empty tree.
+func (t *Tree) All(fn func(key interface{}, value interface{}) bool) bool {
+	all := true
+	it := t.Iterator()
+	for ok := it.SeekFirst(); ok && all; ok = it.Next() {
+		if !fn(it.Key(), it.Value()) {
+			all = false
+		}
+	}
+	return all
+}
+
+// Find returns the first key/value pair, in ascending key order, for
+// which fn returns true.
+func (t *Tree) Find(fn func(key interface{}, value interface{}) bool) (bool, interface{}, interface{}) {
+	it := t.Iterator()
+	for ok := it.SeekFirst(); ok; ok = it.Next() {
+		if fn(it.Key(), it.Value()) {
+			return true, it.Key(), it.Value()
+		}
+	}
+	return false, nil, nil
+}
+
+// Reduce folds over t in ascending key order, starting from initial.
+func (t *Tree) Reduce(initial interface{}, fn func(acc interface{}, key interface{}, value interface{}) interface{}) interface{} {
+	acc := initial
+	t.Each(func(key interface{}, value interface{}) {
+		acc = fn(acc, key, value)
+	})
+	return acc
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2014 Gavin Bong.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+either express or implied. See the License for the specific
+language governing permissions and limitations under the
+License.
+*/
+
+package redblacktree
+
+import "testing"
+
+func TestEach(t *testing.T) {
+    t1 := buildTreeDataTree()
+    var keys []int
+    t1.Each(func(key interface{}, value interface{}) {
+        keys = append(keys, key.(int))
+    })
+    if len(keys) != len(treeData) {
+        t.Fatalf("Expected %d keys got %d", len(treeData), len(keys))
+    }
+    for i := 1; i < len(keys); i++ {
+        if keys[i-1] >= keys[i] {
+            t.Fatalf("Expected ascending order, got %v", keys)
+        }
+    }
+}
+
+func TestSelectKeysGreaterThan20(t *testing.T) {
+    t1 := buildTreeDataTree()
+    result := t1.Select(func(key interface{}, value interface{}) bool {
+        return key.(int) > 20
+    })
+    assertEqualTree(result, t, "(((.22.)26(.30.))35((.45.)83((.85.)90(.100.))))")
+}
+
+func TestMapDoublesKeys(t *testing.T) {
+    t1 := NewTree()
+    t1.Put(1, "a")
+    t1.Put(2, "b")
+    t1.Put(3, "c")
+
+    result := t1.Map(func(key interface{}, value interface{}) (interface{}, interface{}) {
+        return key.(int) * 2, value
+    })
+    assertEqualTree(result, t, "((.2.)4(.6.))")
+}
+
+func TestAnyAllFind(t *testing.T) {
+    t1 := buildTreeDataTree()
+
+    True(t1.Any(func(key interface{}, value interface{}) bool { return key.(int) == 45 }), t)
+    False(t1.Any(func(key interface{}, value interface{}) bool { return key.(int) == 999 }), t)
+
+    True(t1.All(func(key interface{}, value interface{}) bool { return key.(int) > 0 }), t)
+    False(t1.All(func(key interface{}, value interface{}) bool { return key.(int) < 50 }), t)
+
+    ok, k, v := t1.Find(func(key interface{}, value interface{}) bool { return key.(int) > 20 })
+    True(ok, t)
+    if k.(int) != 22 {
+        t.Errorf("Expected 22 got %d", k)
+    }
+    assertPayloadString("payload22", v.(string), t)
+
+    ok, _, _ = t1.Find(func(key interface{}, value interface{}) bool { return key.(int) > 1000 })
+    False(ok, t)
+}
+
+func TestReduceSumsKeys(t *testing.T) {
+    t1 := buildTreeDataTree()
+    sum := t1.Reduce(0, func(acc interface{}, key interface{}, value interface{}) interface{} {
+        return acc.(int) + key.(int)
+    })
+
+    expected := 0
+    for _, tt := range treeData {
+        expected += tt.kv.key
+    }
+    if sum.(int) != expected {
+        t.Errorf("Expected %d got %d", expected, sum)
+    }
+}
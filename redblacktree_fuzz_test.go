@@ -0,0 +1,143 @@
+/*
+Copyright 2014 Gavin Bong.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+either express or implied. See the License for the specific
+language governing permissions and limitations under the
+License.
+*/
+
+package redblacktree
+
+import (
+    "sort"
+    "testing"
+)
+
+// encodeFuzzOp appends one scripted operation to buf. Every op is
+// 3 bytes: opcode, then a big-endian int16 key. opPut ops are
+// followed by the bytes of val and a terminating zero byte.
+const (
+    opPut byte = iota
+    opGet
+    opDelete
+)
+
+func encodeFuzzOp(buf []byte, op byte, key int, val string) []byte {
+    buf = append(buf, op, byte(key>>8), byte(key))
+    if op == opPut {
+        buf = append(buf, val...)
+        buf = append(buf, 0)
+    }
+    return buf
+}
+
+// FuzzTree replays data as a script of put/get/delete operations
+// against both a Tree and a shadow map[int]string, checking after
+// every op that the red-black invariants hold and that the tree
+// agrees with the shadow map.
+func FuzzTree(f *testing.F) {
+    var seed []byte
+    for _, tt := range treeData {
+        seed = encodeFuzzOp(seed, opPut, tt.kv.key, tt.kv.arg)
+    }
+    f.Add(seed)
+
+    seed = nil
+    for _, tt := range fixtureSize {
+        switch tt.ops {
+        case "put":
+            seed = encodeFuzzOp(seed, opPut, tt.kv.key, tt.kv.arg)
+        case "get":
+            seed = encodeFuzzOp(seed, opGet, tt.kv.key, "")
+        }
+    }
+    f.Add(seed)
+
+    seed = nil
+    for _, tt := range fixtureDelete {
+        seed = encodeFuzzOp(seed, opDelete, tt.key, "")
+    }
+    f.Add(seed)
+
+    f.Fuzz(func(t *testing.T, data []byte) {
+        tr := NewTree()
+        shadow := make(map[int]string)
+
+        for len(data) >= 3 {
+            op := data[0] % 3
+            key := int(int16(uint16(data[1])<<8 | uint16(data[2])))
+            data = data[3:]
+
+            switch op {
+            case opPut:
+                end := 0
+                for end < len(data) && data[end] != 0 {
+                    end++
+                }
+                val := string(data[:end])
+                if end < len(data) {
+                    data = data[end+1:]
+                } else {
+                    data = data[end:]
+                }
+                tr.Put(key, val)
+                shadow[key] = val
+
+            case opGet:
+                ok, got := tr.Get(key)
+                want, wantOk := shadow[key]
+                if ok != wantOk {
+                    t.Fatalf("Get(%d) ok=%v, want %v", key, ok, wantOk)
+                }
+                if ok && got.(string) != want {
+                    t.Fatalf("Get(%d)=%q, want %q", key, got, want)
+                }
+
+            case opDelete:
+                ok, got := tr.Delete(key)
+                want, wantOk := shadow[key]
+                if ok != wantOk {
+                    t.Fatalf("Delete(%d) ok=%v, want %v", key, ok, wantOk)
+                }
+                if ok && got.(string) != want {
+                    t.Fatalf("Delete(%d)=%q, want %q", key, got, want)
+                }
+                delete(shadow, key)
+            }
+
+            assertRedBlackInvariants(tr, t)
+            if tr.Size() != uint64(len(shadow)) {
+                t.Fatalf("Size()=%d, want %d", tr.Size(), len(shadow))
+            }
+            assertSorted(tr, t)
+        }
+    })
+}
+
+// assertSorted walks tr in-order and fails t if the keys are not
+// strictly increasing.
+func assertSorted(tr *Tree, t *testing.T) {
+    var keys []int
+    var walk func(n *Node)
+    walk = func(n *Node) {
+        if n == nil {
+            return
+        }
+        walk(n.left)
+        keys = append(keys, n.value.(int))
+        walk(n.right)
+    }
+    walk(tr.root)
+    if !sort.IntsAreSorted(keys) {
+        t.Fatalf("inorder keys not sorted: %v", keys)
+    }
+}
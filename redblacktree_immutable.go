@@ -0,0 +1,278 @@
+/*
+Copyright 2014 Gavin Bong.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+either express or implied. See the License for the specific
+language governing permissions and limitations under the
+License.
+*/
+
+package redblacktree
+
+import "github.com/andir/redblacktree/comparator"
+
+// ImmutableTree is a persistent, copy-on-write red-black tree keyed
+// by whatever type cmp orders. Every mutation happens through a Txn
+// and produces a new ImmutableTree; existing snapshots are never
+// modified and may be read concurrently without locking.
+//
+// Internally it is a left-leaning red-black tree (Sedgewick's
+// variant): insert and delete are expressed as plain recursive
+// functions that clone a node before changing any of its fields, so
+// only the nodes on the path from the root to the change (plus any
+// rotated neighbours) are ever copied.
+type ImmutableTree struct {
+	root *Node
+	size uint64
+	cmp  Comparator
+}
+
+// NewImmutableTree returns an empty ImmutableTree keyed by int.
+func NewImmutableTree() *ImmutableTree {
+	return NewImmutableTreeWith(comparator.Int)
+}
+
+// NewImmutableTreeWith returns an empty ImmutableTree ordered by cmp.
+func NewImmutableTreeWith(cmp Comparator) *ImmutableTree {
+	return &ImmutableTree{cmp: cmp}
+}
+
+// Size returns the number of keys in the tree.
+func (it *ImmutableTree) Size() uint64 {
+	return it.size
+}
+
+// Get looks up key and reports whether it is present, along with its
+// associated value.
+func (it *ImmutableTree) Get(key interface{}) (bool, interface{}) {
+	return searchNode(it.cmp, it.root, key)
+}
+
+func searchNode(cmp Comparator, n *Node, key interface{}) (bool, interface{}) {
+	for n != nil {
+		switch c := cmp(key, n.value); {
+		case c == 0:
+			return true, n.data
+		case c < 0:
+			n = n.left
+		default:
+			n = n.right
+		}
+	}
+	return false, nil
+}
+
+// Walk hands the tree's root to v; any further traversal, such as
+// InorderVisitor's in-order recursion, is done by v itself.
+func (it *ImmutableTree) Walk(v Visitor) {
+	v.Visit(it.root)
+}
+
+// Txn is an in-progress, mutable view onto an ImmutableTree. Put and
+// Delete clone nodes as needed so that the ImmutableTree the Txn was
+// created from is never modified; Commit hands back the result as a
+// new, independent ImmutableTree.
+type Txn struct {
+	root *Node
+	size uint64
+	cmp  Comparator
+}
+
+// Txn starts a new transaction rooted at it's current snapshot.
+func (it *ImmutableTree) Txn() *Txn {
+	return &Txn{root: it.root, size: it.size, cmp: it.cmp}
+}
+
+// Get looks up key against the transaction's current (uncommitted)
+// view of the tree.
+func (txn *Txn) Get(key interface{}) (bool, interface{}) {
+	return searchNode(txn.cmp, txn.root, key)
+}
+
+// Put inserts key/data into the transaction, or overwrites data if
+// key is already present.
+func (txn *Txn) Put(key interface{}, data interface{}) {
+	var inserted bool
+	txn.root = immutablePut(txn.cmp, txn.root, key, data, &inserted)
+	txn.root.color = black
+	if inserted {
+		txn.size++
+	}
+}
+
+// Delete removes key from the transaction, reporting whether it was
+// present and, if so, the value it held.
+func (txn *Txn) Delete(key interface{}) (bool, interface{}) {
+	ok, data := txn.Get(key)
+	if !ok {
+		return false, nil
+	}
+	var removed bool
+	txn.root = immutableDelete(txn.cmp, txn.root, key, &removed)
+	if txn.root != nil {
+		txn.root.color = black
+	}
+	if removed {
+		txn.size--
+	}
+	return true, data
+}
+
+// Commit finalizes the transaction and returns the resulting
+// snapshot. The ImmutableTree the Txn was created from is untouched.
+func (txn *Txn) Commit() *ImmutableTree {
+	return &ImmutableTree{root: txn.root, size: txn.size, cmp: txn.cmp}
+}
+
+func clone(n *Node) *Node {
+	c := *n
+	return &c
+}
+
+func immutablePut(cmp Comparator, h *Node, key interface{}, data interface{}, inserted *bool) *Node {
+	if h == nil {
+		*inserted = true
+		return &Node{value: key, data: data, color: red}
+	}
+	h = clone(h)
+	switch c := cmp(key, h.value); {
+	case c == 0:
+		h.data = data
+	case c < 0:
+		h.left = immutablePut(cmp, h.left, key, data, inserted)
+	default:
+		h.right = immutablePut(cmp, h.right, key, data, inserted)
+	}
+
+	if isRed(h.right) && !isRed(h.left) {
+		h = rotateLeftPersist(h)
+	}
+	if isRed(h.left) && isRed(h.left.left) {
+		h = rotateRightPersist(h)
+	}
+	if isRed(h.left) && isRed(h.right) {
+		flipColorsPersist(h)
+	}
+	return h
+}
+
+func immutableDeleteMin(h *Node) *Node {
+	if h.left == nil {
+		return nil
+	}
+	h = clone(h)
+	if !isRed(h.left) && !isRed(h.left.left) {
+		h = moveRedLeftPersist(h)
+	}
+	h.left = immutableDeleteMin(h.left)
+	return balancePersist(h)
+}
+
+func immutableDelete(cmp Comparator, h *Node, key interface{}, removed *bool) *Node {
+	if h == nil {
+		return nil
+	}
+	h = clone(h)
+
+	if cmp(key, h.value) < 0 {
+		if h.left != nil && !isRed(h.left) && !isRed(h.left.left) {
+			h = moveRedLeftPersist(h)
+		}
+		h.left = immutableDelete(cmp, h.left, key, removed)
+	} else {
+		if isRed(h.left) {
+			h = rotateRightPersist(h)
+		}
+		if cmp(key, h.value) == 0 && h.right == nil {
+			*removed = true
+			return nil
+		}
+		if h.right != nil && !isRed(h.right) && !isRed(h.right.left) {
+			h = moveRedRightPersist(h)
+		}
+		if cmp(key, h.value) == 0 {
+			*removed = true
+			m := minimum(h.right)
+			h.value, h.data = m.value, m.data
+			h.right = immutableDeleteMin(h.right)
+		} else {
+			h.right = immutableDelete(cmp, h.right, key, removed)
+		}
+	}
+	return balancePersist(h)
+}
+
+// rotateLeftPersist and rotateRightPersist mirror Tree.RotateLeft /
+// Tree.RotateRight, but work on parentless, possibly-shared nodes:
+// the pivot is cloned before any of its fields are changed, so a
+// node that is still referenced by another snapshot is never
+// mutated in place.
+func rotateLeftPersist(h *Node) *Node {
+	x := clone(h.right)
+	h.right = x.left
+	x.left = h
+	x.color = h.color
+	h.color = red
+	return x
+}
+
+func rotateRightPersist(h *Node) *Node {
+	x := clone(h.left)
+	h.left = x.right
+	x.right = h
+	x.color = h.color
+	h.color = red
+	return x
+}
+
+func flipColorsPersist(h *Node) {
+	h.color = !h.color
+	if h.left != nil {
+		h.left = clone(h.left)
+		h.left.color = !h.left.color
+	}
+	if h.right != nil {
+		h.right = clone(h.right)
+		h.right.color = !h.right.color
+	}
+}
+
+func moveRedLeftPersist(h *Node) *Node {
+	flipColorsPersist(h)
+	if h.right != nil && isRed(h.right.left) {
+		h.right = rotateRightPersist(h.right)
+		h = rotateLeftPersist(h)
+		flipColorsPersist(h)
+	}
+	return h
+}
+
+func moveRedRightPersist(h *Node) *Node {
+	flipColorsPersist(h)
+	if h.left != nil && isRed(h.left.left) {
+		h = rotateRightPersist(h)
+		flipColorsPersist(h)
+	}
+	return h
+}
+
+func balancePersist(h *Node) *Node {
+	if isRed(h.right) && !isRed(h.left) {
+		h = rotateLeftPersist(h)
+	}
+	if isRed(h.left) && isRed(h.left.left) {
+		h = rotateRightPersist(h)
+	}
+	if isRed(h.left) && isRed(h.right) {
+		flipColorsPersist(h)
+	}
+	return h
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2014 Gavin Bong.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+either express or implied. See the License for the specific
+language governing permissions and limitations under the
+License.
+*/
+
+package redblacktree
+
+import (
+    "fmt"
+    "testing"
+)
+
+func assertEqualImmutableTree(it *ImmutableTree, t *testing.T, expected string) {
+    visitor := &InorderVisitor{}
+    it.Walk(visitor)
+    if visitor.String() != expected {
+        t.Errorf("Expected [ %s ] got [ %s ]", expected, visitor)
+    }
+}
+
+func TestImmutableTreePutGet(t *testing.T) {
+    it := NewImmutableTree()
+    assertEqualImmutableTree(it, t, ".")
+
+    txn := it.Txn()
+    for _, tt := range treeData {
+        txn.Put(tt.kv.key, tt.kv.arg)
+    }
+    it2 := txn.Commit()
+
+    assertEqualImmutableTree(it, t, ".")
+    assertEqual(0, it.Size(), t)
+
+    assertEqualImmutableTree(it2, t, "((((.3.)7(.8.))10((.11.)18(.22.)))26(((.30.)35((.45.)83.))85((.90.)100.)))")
+    assertEqual(uint64(len(treeData)), it2.Size(), t)
+
+    ok, payload := it2.Get(18)
+    True(ok, t)
+    assertPayloadString("payload18", payload.(string), t)
+
+    ok, _ = it2.Get(999)
+    False(ok, t)
+}
+
+func TestImmutableTreeDelete(t *testing.T) {
+    txn := NewImmutableTree().Txn()
+    for _, tt := range treeData {
+        txn.Put(tt.kv.key, tt.kv.arg)
+    }
+    it := txn.Commit()
+
+    del := it.Txn()
+    ok, payload := del.Delete(8)
+    True(ok, t)
+    assertPayloadString("payload8", payload.(string), t)
+    it2 := del.Commit()
+
+    // the older snapshot is untouched by the delete against the
+    // newer transaction.
+    assertEqualImmutableTree(it, t, "((((.3.)7(.8.))10((.11.)18(.22.)))26(((.30.)35((.45.)83.))85((.90.)100.)))")
+    assertEqual(uint64(len(treeData)), it.Size(), t)
+
+    ok, _ = it2.Get(8)
+    False(ok, t)
+    assertEqual(uint64(len(treeData)-1), it2.Size(), t)
+}
+
+// TestImmutableTreeSnapshotIsolation mutates a transaction while an
+// older snapshot is mid-walk, and confirms the older snapshot's
+// inorder string never changes.
+func TestImmutableTreeSnapshotIsolation(t *testing.T) {
+    txn := NewImmutableTree().Txn()
+    for _, tt := range treeData {
+        txn.Put(tt.kv.key, tt.kv.arg)
+    }
+    snapshot := txn.Commit()
+
+    before := &InorderVisitor{}
+    snapshot.Walk(before)
+    expected := before.String()
+
+    // render the root and its left subtree, mutate, then render the
+    // right subtree, so the txn's changes land mid-walk.
+    during := &InorderVisitor{}
+    during.buf.WriteString("(")
+    during.Visit(snapshot.root.left)
+    fmt.Fprintf(&during.buf, "%v", snapshot.root.value)
+
+    later := snapshot.Txn()
+    later.Put(999, "payload999")
+    later.Delete(7)
+    later.Put(3, "payload3+")
+    _ = later.Commit()
+
+    during.Visit(snapshot.root.right)
+    during.buf.WriteString(")")
+    if during.String() != expected {
+        t.Errorf("snapshot mutated mid-walk: was [ %s ], now [ %s ]", expected, during.String())
+    }
+
+    after := &InorderVisitor{}
+    snapshot.Walk(after)
+    if after.String() != expected {
+        t.Errorf("snapshot mutated by a later txn: was [ %s ], now [ %s ]", expected, after.String())
+    }
+}
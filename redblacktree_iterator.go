@@ -0,0 +1,243 @@
+/*
+Copyright 2014 Gavin Bong.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+either express or implied. See the License for the specific
+language governing permissions and limitations under the
+License.
+*/
+
+package redblacktree
+
+// Iterator is a pull-based, bidirectional cursor over a Tree's keys
+// in sorted order. It keeps the ancestor path from the root down to
+// the current node on an explicit stack, so Next and Prev run in
+// amortized O(1) without recursion and without relying on the
+// mutable Tree's parent pointers.
+//
+// The zero value is not usable; obtain one via Tree.Iterator or
+// Tree.Range.
+type Iterator struct {
+	tree  *Tree
+	stack []*Node
+
+	hasHi       bool
+	hi          interface{}
+	hiInclusive bool
+}
+
+// Iterator returns a new, initially invalid Iterator over t. Call one
+// of the Seek methods to position it before reading Key/Value.
+func (t *Tree) Iterator() *Iterator {
+	return &Iterator{tree: t}
+}
+
+// Valid reports whether the iterator is positioned at a key.
+func (it *Iterator) Valid() bool {
+	return len(it.stack) > 0
+}
+
+// Key returns the key at the iterator's current position. It panics
+// if the iterator is not Valid.
+func (it *Iterator) Key() interface{} {
+	return it.stack[len(it.stack)-1].value
+}
+
+// Value returns the value at the iterator's current position. It
+// panics if the iterator is not Valid.
+func (it *Iterator) Value() interface{} {
+	return it.stack[len(it.stack)-1].data
+}
+
+func (it *Iterator) reset() {
+	it.stack = it.stack[:0]
+}
+
+func (it *Iterator) pushLeftSpine(n *Node) {
+	for n != nil {
+		it.stack = append(it.stack, n)
+		n = n.left
+	}
+}
+
+func (it *Iterator) pushRightSpine(n *Node) {
+	for n != nil {
+		it.stack = append(it.stack, n)
+		n = n.right
+	}
+}
+
+// SeekFirst positions the iterator at the smallest key in the tree.
+func (it *Iterator) SeekFirst() bool {
+	it.reset()
+	it.pushLeftSpine(it.tree.root)
+	return it.Valid()
+}
+
+// SeekLast positions the iterator at the largest key in the tree.
+func (it *Iterator) SeekLast() bool {
+	it.reset()
+	it.pushRightSpine(it.tree.root)
+	return it.Valid()
+}
+
+// SeekExact positions the iterator at key, reporting whether it was
+// found. On a miss the iterator becomes invalid.
+func (it *Iterator) SeekExact(key interface{}) bool {
+	var path []*Node
+	n := it.tree.root
+	for n != nil {
+		path = append(path, n)
+		switch c := it.tree.cmp(key, n.value); {
+		case c == 0:
+			it.stack = path
+			return true
+		case c < 0:
+			n = n.left
+		default:
+			n = n.right
+		}
+	}
+	it.stack = nil
+	return false
+}
+
+// SeekGE positions the iterator at the smallest key >= key (its
+// ceiling), reporting whether one exists.
+func (it *Iterator) SeekGE(key interface{}) bool {
+	var path, best []*Node
+	n := it.tree.root
+	for n != nil {
+		path = append(path, n)
+		if it.tree.cmp(n.value, key) >= 0 {
+			best = append([]*Node(nil), path...)
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	it.stack = best
+	return it.Valid()
+}
+
+// SeekLE positions the iterator at the largest key <= key (its
+// floor), reporting whether one exists.
+func (it *Iterator) SeekLE(key interface{}) bool {
+	var path, best []*Node
+	n := it.tree.root
+	for n != nil {
+		path = append(path, n)
+		if it.tree.cmp(n.value, key) <= 0 {
+			best = append([]*Node(nil), path...)
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	it.stack = best
+	return it.Valid()
+}
+
+// Next advances to the next key in ascending order, reporting
+// whether one exists. It invalidates the iterator when the current
+// key was the last one.
+func (it *Iterator) Next() bool {
+	if !it.advance() {
+		return false
+	}
+	it.clampHi()
+	return it.Valid()
+}
+
+func (it *Iterator) advance() bool {
+	if len(it.stack) == 0 {
+		return false
+	}
+	cur := it.stack[len(it.stack)-1]
+	if cur.right != nil {
+		it.pushLeftSpine(cur.right)
+		return true
+	}
+	for len(it.stack) > 0 {
+		child := it.stack[len(it.stack)-1]
+		it.stack = it.stack[:len(it.stack)-1]
+		if len(it.stack) > 0 && it.stack[len(it.stack)-1].left == child {
+			return true
+		}
+	}
+	return false
+}
+
+// Prev moves to the previous key in ascending order (i.e. the next
+// one in descending order), reporting whether one exists. It
+// invalidates the iterator when the current key was the first one.
+func (it *Iterator) Prev() bool {
+	if len(it.stack) == 0 {
+		return false
+	}
+	cur := it.stack[len(it.stack)-1]
+	if cur.left != nil {
+		it.pushRightSpine(cur.left)
+		return true
+	}
+	for len(it.stack) > 0 {
+		child := it.stack[len(it.stack)-1]
+		it.stack = it.stack[:len(it.stack)-1]
+		if len(it.stack) > 0 && it.stack[len(it.stack)-1].right == child {
+			return true
+		}
+	}
+	return false
+}
+
+// clampHi invalidates the iterator once it has walked past the upper
+// bound set by Range.
+func (it *Iterator) clampHi() {
+	if !it.hasHi || !it.Valid() {
+		return
+	}
+	c := it.tree.cmp(it.Key(), it.hi)
+	if (it.hiInclusive && c > 0) || (!it.hiInclusive && c >= 0) {
+		it.stack = nil
+	}
+}
+
+// Floor reports the entry with the largest key <= key, if any.
+func (t *Tree) Floor(key interface{}) (bool, interface{}, interface{}) {
+	it := t.Iterator()
+	if !it.SeekLE(key) {
+		return false, nil, nil
+	}
+	return true, it.Key(), it.Value()
+}
+
+// Ceiling reports the entry with the smallest key >= key, if any.
+func (t *Tree) Ceiling(key interface{}) (bool, interface{}, interface{}) {
+	it := t.Iterator()
+	if !it.SeekGE(key) {
+		return false, nil, nil
+	}
+	return true, it.Key(), it.Value()
+}
+
+// Range returns an Iterator positioned at the start of [lo, hi) (or
+// [lo, hi] when inclusive is true), ready for a forward scan via
+// Next. lo itself is included if present and within that range; the
+// iterator starts out invalid when the range is empty.
+func (t *Tree) Range(lo, hi interface{}, inclusive bool) *Iterator {
+	it := t.Iterator()
+	it.SeekGE(lo)
+	it.hasHi = true
+	it.hi = hi
+	it.hiInclusive = inclusive
+	it.clampHi()
+	return it
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2014 Gavin Bong.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+either express or implied. See the License for the specific
+language governing permissions and limitations under the
+License.
+*/
+
+package redblacktree
+
+import "testing"
+
+func buildTreeDataTree() *Tree {
+    t1 := NewTree()
+    for _, tt := range treeData {
+        t1.Put(tt.kv.key, tt.kv.arg)
+    }
+    return t1
+}
+
+func TestIteratorForward(t *testing.T) {
+    t1 := buildTreeDataTree()
+    it := t1.Iterator()
+    True(it.SeekFirst(), t)
+
+    var keys []int
+    for it.Valid() {
+        keys = append(keys, it.Key().(int))
+        it.Next()
+    }
+
+    expected := []int{3, 7, 8, 10, 11, 18, 22, 26, 30, 35, 45, 83, 85, 90, 100}
+    if len(keys) != len(expected) {
+        t.Fatalf("got %v, want %v", keys, expected)
+    }
+    for i := range expected {
+        if keys[i] != expected[i] {
+            t.Fatalf("got %v, want %v", keys, expected)
+        }
+    }
+}
+
+func TestIteratorBackward(t *testing.T) {
+    t1 := buildTreeDataTree()
+    it := t1.Iterator()
+    True(it.SeekLast(), t)
+
+    var keys []int
+    for it.Valid() {
+        keys = append(keys, it.Key().(int))
+        it.Prev()
+    }
+
+    expected := []int{100, 90, 85, 83, 45, 35, 30, 26, 22, 18, 11, 10, 8, 7, 3}
+    if len(keys) != len(expected) {
+        t.Fatalf("got %v, want %v", keys, expected)
+    }
+    for i := range expected {
+        if keys[i] != expected[i] {
+            t.Fatalf("got %v, want %v", keys, expected)
+        }
+    }
+}
+
+func TestIteratorChangeDirection(t *testing.T) {
+    t1 := buildTreeDataTree()
+    it := t1.Iterator()
+    True(it.SeekExact(18), t)
+
+    True(it.Next(), t)
+    if it.Key() != 22 {
+        t.Errorf("Expected 22 got %d", it.Key())
+    }
+
+    True(it.Prev(), t)
+    if it.Key() != 18 {
+        t.Errorf("Expected 18 got %d", it.Key())
+    }
+
+    True(it.Prev(), t)
+    if it.Key() != 11 {
+        t.Errorf("Expected 11 got %d", it.Key())
+    }
+}
+
+func TestIteratorSeekMiss(t *testing.T) {
+    t1 := buildTreeDataTree()
+    it := t1.Iterator()
+    False(it.SeekExact(999), t)
+    False(it.Valid(), t)
+}
+
+func TestFloorCeiling(t *testing.T) {
+    t1 := buildTreeDataTree()
+
+    ok, k, v := t1.Ceiling(9)
+    True(ok, t)
+    if k != 10 {
+        t.Errorf("Expected 10 got %d", k)
+    }
+    assertPayloadString("payload10", v.(string), t)
+
+    ok, k, v = t1.Floor(9)
+    True(ok, t)
+    if k != 8 {
+        t.Errorf("Expected 8 got %d", k)
+    }
+    assertPayloadString("payload8", v.(string), t)
+
+    ok, k, _ = t1.Ceiling(3)
+    True(ok, t)
+    if k != 3 {
+        t.Errorf("Expected 3 got %d", k)
+    }
+
+    ok, _, _ = t1.Ceiling(101)
+    False(ok, t)
+
+    ok, _, _ = t1.Floor(2)
+    False(ok, t)
+}
+
+func TestRange(t *testing.T) {
+    t1 := buildTreeDataTree()
+
+    it := t1.Range(10, 30, false)
+    var keys []int
+    for it.Valid() {
+        keys = append(keys, it.Key().(int))
+        it.Next()
+    }
+    expected := []int{10, 11, 18, 22, 26}
+    if len(keys) != len(expected) {
+        t.Fatalf("got %v, want %v", keys, expected)
+    }
+    for i := range expected {
+        if keys[i] != expected[i] {
+            t.Fatalf("got %v, want %v", keys, expected)
+        }
+    }
+
+    it = t1.Range(10, 30, true)
+    keys = nil
+    for it.Valid() {
+        keys = append(keys, it.Key().(int))
+        it.Next()
+    }
+    expected = []int{10, 11, 18, 22, 26, 30}
+    if len(keys) != len(expected) {
+        t.Fatalf("got %v, want %v", keys, expected)
+    }
+    for i := range expected {
+        if keys[i] != expected[i] {
+            t.Fatalf("got %v, want %v", keys, expected)
+        }
+    }
+}
@@ -28,7 +28,7 @@ var funcs map[string]reflect.Method
 
 func init() {
     var found bool
-    var put, get, rotateLeft, rotateRight reflect.Method
+    var put, get, del, rotateLeft, rotateRight reflect.Method
 
     t := reflect.TypeOf(NewTree())
     put, found = t.MethodByName("Put")
@@ -39,6 +39,10 @@ func init() {
     if !found {
         panic("No method `Get` in Tree")
     }
+    del, found = t.MethodByName("Delete")
+    if !found {
+        panic("No method `Delete` in Tree")
+    }
     rotateLeft, found = t.MethodByName("RotateLeft")
     if !found {
         panic("No method `RotateLeft` in Tree")
@@ -53,6 +57,7 @@ func init() {
         "rotateLeft":  rotateLeft,
         "put":         put,
         "get":         get,
+        "delete":      del,
     }
 
     TraceOff()
@@ -535,7 +540,6 @@ func TestIsRed(t *testing.T) {
     }
 }
 
-// @TODO add deletes to the mix
 var fixtureSize = []struct {
     ops      string
     kv       KV
@@ -551,6 +555,9 @@ var fixtureSize = []struct {
     {"get", KV{9, "payload9"}, 3},
     {"put", KV{9, "payload9+"}, 3},
     {"get", KV{9, "payload9+"}, 3},
+    {"delete", KV{9, "payload9+"}, 2},
+    {"delete", KV{1, "payload1+"}, 1},
+    {"delete", KV{7, "payload7"}, 0},
 }
 
 func TestSize(t *testing.T) {
@@ -562,7 +569,7 @@ func TestSize(t *testing.T) {
             method.Func.Call(ToArgs(t1, tt.kv.key, tt.kv.arg))
         case tt.ops == "1st":
             // noop
-        case tt.ops == "get":
+        case tt.ops == "get", tt.ops == "delete":
             result := method.Func.Call(ToArgs(t1, tt.kv.key))
             //fmt.Printf("%T %#v %d\n", result, result, len(result))
             if result[0].Kind() != reflect.Bool {
@@ -577,3 +584,64 @@ func TestSize(t *testing.T) {
         assertEqual(tt.expected, t1.Size(), t)
     }
 }
+
+// fixtureDelete deletes keys out of the treeData tree one at a time,
+// checking the resulting inorder string after each removal.
+var fixtureDelete = []struct {
+    key      int
+    expected string
+}{
+    {8, "((((.3.)7.)10((.11.)18(.22.)))26((.30.)35((.45(.83.))85(.90(.100.)))))"},
+    {22, "((((.3.)7.)10((.11.)18.))26((.30.)35((.45(.83.))85(.90(.100.)))))"},
+    {10, "((((.3.)7.)11(.18.))26((.30.)35((.45(.83.))85(.90(.100.)))))"},
+    {999, "((((.3.)7.)11(.18.))26((.30.)35((.45(.83.))85(.90(.100.)))))"},
+}
+
+func TestRedBlackDelete(t *testing.T) {
+    t1 := NewTree()
+    for _, tt := range treeData {
+        method := funcs[tt.ops]
+        switch {
+        case tt.ops == "put":
+            method.Func.Call(ToArgs(t1, tt.kv.key, tt.kv.arg))
+        }
+    }
+
+    for _, tt := range fixtureDelete {
+        t1.Delete(tt.key)
+        assertEqualTree(t1, t, tt.expected)
+        assertRedBlackInvariants(t1, t)
+    }
+
+    ok, payload := t1.Delete(8)
+    False(ok, t)
+    Nil(payload, t)
+}
+
+// assertRedBlackInvariants walks tr and fails t if the root isn't
+// black, a red node parents a red child, or any two root-to-nil
+// paths disagree on black height.
+func assertRedBlackInvariants(tr *Tree, t *testing.T) {
+    if isRed(tr.root) {
+        t.Errorf("root is red")
+    }
+    var walk func(n *Node) int
+    walk = func(n *Node) int {
+        if n == nil {
+            return 1
+        }
+        if isRed(n) && (isRed(n.left) || isRed(n.right)) {
+            t.Errorf("red node %v has a red child", n.value)
+        }
+        left := walk(n.left)
+        right := walk(n.right)
+        if left != right {
+            t.Errorf("black height mismatch at %v: %d vs %d", n.value, left, right)
+        }
+        if isRed(n) {
+            return left
+        }
+        return left + 1
+    }
+    walk(tr.root)
+}
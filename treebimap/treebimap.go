@@ -0,0 +1,193 @@
+/*
+Copyright 2014 Gavin Bong.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+either express or implied. See the License for the specific
+language governing permissions and limitations under the
+License.
+*/
+
+// Package treebimap provides an ordered, two-way lookup structure
+// built on top of a pair of redblacktree.Tree, one for each
+// direction. It reuses the tree's balancing rather than
+// reimplementing it.
+package treebimap
+
+import "github.com/andir/redblacktree"
+
+// BiMap is a one-to-one mapping between keys and values, both
+// orderable via their own Comparator, that supports lookup from
+// either side. fwd holds key -> value, inv holds value -> key; the
+// two are kept in lockstep by Put, RemoveByKey and RemoveByValue.
+type BiMap struct {
+	fwd *redblacktree.Tree
+	inv *redblacktree.Tree
+
+	keyCmp   redblacktree.Comparator
+	valueCmp redblacktree.Comparator
+}
+
+// NewBiMap returns an empty BiMap ordering keys by keyCmp and values
+// by valueCmp.
+func NewBiMap(keyCmp, valueCmp redblacktree.Comparator) *BiMap {
+	return &BiMap{
+		fwd:      redblacktree.NewTreeWith(keyCmp),
+		inv:      redblacktree.NewTreeWith(valueCmp),
+		keyCmp:   keyCmp,
+		valueCmp: valueCmp,
+	}
+}
+
+// Put associates key with value, maintaining the one-to-one
+// invariant: any existing mapping for key, and any existing mapping
+// for value, is evicted before the new pair is inserted.
+func (b *BiMap) Put(key, value interface{}) {
+	if ok, oldValue := b.fwd.Get(key); ok {
+		b.inv.Delete(oldValue)
+	}
+	if ok, oldKey := b.inv.Get(value); ok {
+		b.fwd.Delete(oldKey)
+	}
+	b.fwd.Put(key, value)
+	b.inv.Put(value, key)
+}
+
+// GetByKey looks up the value associated with key.
+func (b *BiMap) GetByKey(key interface{}) (bool, interface{}) {
+	return b.fwd.Get(key)
+}
+
+// GetByValue looks up the key associated with value.
+func (b *BiMap) GetByValue(value interface{}) (bool, interface{}) {
+	return b.inv.Get(value)
+}
+
+// RemoveByKey removes the mapping for key, if any, reporting whether
+// it existed and the value it held.
+func (b *BiMap) RemoveByKey(key interface{}) (bool, interface{}) {
+	ok, value := b.fwd.Delete(key)
+	if ok {
+		b.inv.Delete(value)
+	}
+	return ok, value
+}
+
+// RemoveByValue removes the mapping for value, if any, reporting
+// whether it existed and the key it was bound to.
+func (b *BiMap) RemoveByValue(value interface{}) (bool, interface{}) {
+	ok, key := b.inv.Delete(value)
+	if ok {
+		b.fwd.Delete(key)
+	}
+	return ok, key
+}
+
+// Size returns the number of key/value pairs in the BiMap.
+func (b *BiMap) Size() uint64 {
+	return b.fwd.Size()
+}
+
+// Iterator is a pull-based cursor over a BiMap's pairs, sorted by
+// either key or value depending on which BiMap method produced it.
+// Key and Value always return the pair's key and value respectively,
+// regardless of the sort order.
+type Iterator struct {
+	it      *redblacktree.Iterator
+	byValue bool
+}
+
+// Iterator returns an Iterator over the BiMap's pairs in ascending
+// key order.
+func (b *BiMap) Iterator() *Iterator {
+	return &Iterator{it: b.fwd.Iterator()}
+}
+
+// IteratorByValue returns an Iterator over the BiMap's pairs in
+// ascending value order.
+func (b *BiMap) IteratorByValue() *Iterator {
+	return &Iterator{it: b.inv.Iterator(), byValue: true}
+}
+
+// SeekFirst positions the iterator at its first pair.
+func (it *Iterator) SeekFirst() bool {
+	return it.it.SeekFirst()
+}
+
+// SeekLast positions the iterator at its last pair.
+func (it *Iterator) SeekLast() bool {
+	return it.it.SeekLast()
+}
+
+// Next advances to the next pair, reporting whether one exists.
+func (it *Iterator) Next() bool {
+	return it.it.Next()
+}
+
+// Prev moves to the previous pair, reporting whether one exists.
+func (it *Iterator) Prev() bool {
+	return it.it.Prev()
+}
+
+// Valid reports whether the iterator is positioned at a pair.
+func (it *Iterator) Valid() bool {
+	return it.it.Valid()
+}
+
+// Key returns the key of the pair at the iterator's current
+// position.
+func (it *Iterator) Key() interface{} {
+	if it.byValue {
+		return it.it.Value()
+	}
+	return it.it.Key()
+}
+
+// Value returns the value of the pair at the iterator's current
+// position.
+func (it *Iterator) Value() interface{} {
+	if it.byValue {
+		return it.it.Key()
+	}
+	return it.it.Value()
+}
+
+// Each calls fn with every key/value pair in the BiMap, in ascending
+// key order.
+func (b *BiMap) Each(fn func(key, value interface{})) {
+	it := b.Iterator()
+	for ok := it.SeekFirst(); ok; ok = it.Next() {
+		fn(it.Key(), it.Value())
+	}
+}
+
+// Map builds a new BiMap, using the same pair of comparators, by
+// applying fn to every pair of b in ascending key order and Put-ing
+// the result.
+func (b *BiMap) Map(fn func(key, value interface{}) (interface{}, interface{})) *BiMap {
+	result := NewBiMap(b.keyCmp, b.valueCmp)
+	b.Each(func(key, value interface{}) {
+		k, v := fn(key, value)
+		result.Put(k, v)
+	})
+	return result
+}
+
+// Select builds a new BiMap, using the same pair of comparators,
+// containing only the pairs of b for which fn returns true.
+func (b *BiMap) Select(fn func(key, value interface{}) bool) *BiMap {
+	result := NewBiMap(b.keyCmp, b.valueCmp)
+	b.Each(func(key, value interface{}) {
+		if fn(key, value) {
+			result.Put(key, value)
+		}
+	})
+	return result
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2014 Gavin Bong.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+either express or implied. See the License for the specific
+language governing permissions and limitations under the
+License.
+*/
+
+package treebimap
+
+import (
+	"testing"
+
+	"github.com/andir/redblacktree/comparator"
+)
+
+func buildNameByID() *BiMap {
+	b := NewBiMap(comparator.Int, comparator.String)
+	b.Put(3, "carol")
+	b.Put(1, "alice")
+	b.Put(2, "bob")
+	return b
+}
+
+func TestPutGetByKeyAndValue(t *testing.T) {
+	b := buildNameByID()
+
+	ok, name := b.GetByKey(1)
+	if !ok || name.(string) != "alice" {
+		t.Fatalf("GetByKey(1) = %v, %v", ok, name)
+	}
+
+	ok, id := b.GetByValue("bob")
+	if !ok || id.(int) != 2 {
+		t.Fatalf("GetByValue(bob) = %v, %v", ok, id)
+	}
+
+	if b.Size() != 3 {
+		t.Fatalf("Size() = %d, want 3", b.Size())
+	}
+}
+
+func TestPutEvictsConflictingMappings(t *testing.T) {
+	b := buildNameByID()
+
+	// Re-pointing key 1 at a name already bound to key 2 must evict
+	// both the old binding for key 1 and the old binding for "bob".
+	b.Put(1, "bob")
+
+	if ok, _ := b.GetByKey(2); ok {
+		t.Fatalf("GetByKey(2) should have been evicted")
+	}
+	ok, name := b.GetByKey(1)
+	if !ok || name.(string) != "bob" {
+		t.Fatalf("GetByKey(1) = %v, %v, want true, bob", ok, name)
+	}
+	ok, id := b.GetByValue("bob")
+	if !ok || id.(int) != 1 {
+		t.Fatalf("GetByValue(bob) = %v, %v, want true, 1", ok, id)
+	}
+	if b.Size() != 2 {
+		t.Fatalf("Size() = %d, want 2", b.Size())
+	}
+}
+
+func TestRemoveByKeyAndValue(t *testing.T) {
+	b := buildNameByID()
+
+	ok, name := b.RemoveByKey(2)
+	if !ok || name.(string) != "bob" {
+		t.Fatalf("RemoveByKey(2) = %v, %v", ok, name)
+	}
+	if ok, _ := b.GetByValue("bob"); ok {
+		t.Fatalf("GetByValue(bob) should be gone after RemoveByKey(2)")
+	}
+
+	ok, id := b.RemoveByValue("alice")
+	if !ok || id.(int) != 1 {
+		t.Fatalf("RemoveByValue(alice) = %v, %v", ok, id)
+	}
+	if ok, _ := b.GetByKey(1); ok {
+		t.Fatalf("GetByKey(1) should be gone after RemoveByValue(alice)")
+	}
+
+	if b.Size() != 1 {
+		t.Fatalf("Size() = %d, want 1", b.Size())
+	}
+}
+
+func TestIteratorSortsByKeyAndByValue(t *testing.T) {
+	b := buildNameByID()
+
+	var ids []int
+	it := b.Iterator()
+	for ok := it.SeekFirst(); ok; ok = it.Next() {
+		ids = append(ids, it.Key().(int))
+	}
+	wantIDs := []int{1, 2, 3}
+	if len(ids) != len(wantIDs) {
+		t.Fatalf("got %v, want %v", ids, wantIDs)
+	}
+	for i := range wantIDs {
+		if ids[i] != wantIDs[i] {
+			t.Fatalf("got %v, want %v", ids, wantIDs)
+		}
+	}
+
+	var names []string
+	vit := b.IteratorByValue()
+	for ok := vit.SeekFirst(); ok; ok = vit.Next() {
+		names = append(names, vit.Value().(string))
+	}
+	wantNames := []string{"alice", "bob", "carol"}
+	if len(names) != len(wantNames) {
+		t.Fatalf("got %v, want %v", names, wantNames)
+	}
+	for i := range wantNames {
+		if names[i] != wantNames[i] {
+			t.Fatalf("got %v, want %v", names, wantNames)
+		}
+	}
+}
+
+func TestEachMapSelect(t *testing.T) {
+	b := buildNameByID()
+
+	sum := 0
+	b.Each(func(key, value interface{}) {
+		sum += key.(int)
+	})
+	if sum != 6 {
+		t.Fatalf("sum = %d, want 6", sum)
+	}
+
+	doubled := b.Map(func(key, value interface{}) (interface{}, interface{}) {
+		return key.(int) * 2, value
+	})
+	if ok, name := doubled.GetByKey(6); !ok || name.(string) != "carol" {
+		t.Fatalf("doubled.GetByKey(6) = %v, %v", ok, name)
+	}
+
+	selected := b.Select(func(key, value interface{}) bool {
+		return key.(int) >= 2
+	})
+	if selected.Size() != 2 {
+		t.Fatalf("selected.Size() = %d, want 2", selected.Size())
+	}
+	if ok, _ := selected.GetByKey(1); ok {
+		t.Fatalf("selected should not contain key 1")
+	}
+}